@@ -0,0 +1,59 @@
+package chezmoi
+
+import "fmt"
+
+// A SecretFinding is a potential secret detected by a SecretScanner.
+type SecretFinding struct {
+	Scanner     string
+	Rule        string
+	Offset      int
+	Line        int
+	Description string
+}
+
+// String returns f formatted as a single line, with the scanner name
+// prefixed.
+func (f SecretFinding) String() string {
+	return fmt.Sprintf("[%s] %s", f.Scanner, f.Description)
+}
+
+// A SecretScanner scans file contents for potential secrets.
+type SecretScanner interface {
+	// Name returns the scanner's name, as used in configuration and in the
+	// Scanner field of findings reported by Scan.
+	Name() string
+	// Scan scans content, read from path, and returns any findings.
+	Scan(path string, content []byte) []SecretFinding
+}
+
+// DedupeSecretFindings returns findings with duplicates, as determined by the
+// tuple (finding.Scanner, finding.Rule, finding.Line, finding.Offset),
+// removed. Line is included because Offset is not necessarily a whole-file
+// byte offset: some scanners, such as gitleaks, report a column relative to
+// the start of the line, so two distinct findings on different lines can
+// otherwise share the same (Scanner, Rule, Offset). The relative order of
+// the remaining findings is preserved.
+func DedupeSecretFindings(findings []SecretFinding) []SecretFinding {
+	type key struct {
+		scanner string
+		rule    string
+		line    int
+		offset  int
+	}
+	seen := make(map[key]struct{}, len(findings))
+	deduped := make([]SecretFinding, 0, len(findings))
+	for _, finding := range findings {
+		k := key{
+			scanner: finding.Scanner,
+			rule:    finding.Rule,
+			line:    finding.Line,
+			offset:  finding.Offset,
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		deduped = append(deduped, finding)
+	}
+	return deduped
+}
@@ -0,0 +1,122 @@
+package chezmoi
+
+import (
+	"math"
+	"strings"
+)
+
+// entropySecretScannerName is the name used to enable the entropy-only
+// heuristic secret scanner.
+const entropySecretScannerName = "entropy"
+
+// An entropySecretScanner is a SecretScanner that flags tokens of at least
+// minLength characters whose Shannon entropy is at least threshold bits per
+// character. It catches high-entropy strings, such as base64-encoded keys,
+// that don't match any known rule.
+type entropySecretScanner struct {
+	minLength int
+	threshold float64
+}
+
+// NewEntropySecretScanner returns a SecretScanner that reports tokens of at
+// least minLength characters whose Shannon entropy is at least threshold bits
+// per character.
+func NewEntropySecretScanner(minLength int, threshold float64) SecretScanner {
+	return &entropySecretScanner{
+		minLength: minLength,
+		threshold: threshold,
+	}
+}
+
+func (s *entropySecretScanner) Name() string {
+	return entropySecretScannerName
+}
+
+func (s *entropySecretScanner) Scan(path string, content []byte) []SecretFinding {
+	var findings []SecretFinding
+	offset := 0
+	for lineNumber, line := range strings.Split(string(content), "\n") {
+		for _, tokenStart := range tokenStarts(line) {
+			token := line[tokenStart.start:tokenStart.end]
+			if len(token) < s.minLength {
+				continue
+			}
+			if entropy := shannonEntropy(token); entropy >= s.threshold {
+				findings = append(findings, SecretFinding{
+					Scanner:     s.Name(),
+					Rule:        "high-entropy-string",
+					Offset:      offset + tokenStart.start,
+					Line:        lineNumber + 1,
+					Description: "possible high entropy secret",
+				})
+			}
+		}
+		offset += len(line) + 1
+	}
+	return findings
+}
+
+// A tokenSpan is the start and end byte offset, within a single line, of a
+// token matched by tokenStarts.
+type tokenSpan struct {
+	start int
+	end   int
+}
+
+// tokenStarts returns the spans of every maximal run of secret-token runes in
+// line, in order, each with its own start column. Unlike
+// strings.FieldsFunc combined with strings.Index, this correctly
+// distinguishes multiple occurrences of the same token on one line, which
+// would otherwise all resolve to the first occurrence's column.
+func tokenStarts(line string) []tokenSpan {
+	var spans []tokenSpan
+	start := -1
+	for i, r := range line {
+		if isNotSecretTokenRune(r) {
+			if start >= 0 {
+				spans = append(spans, tokenSpan{start: start, end: i})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, tokenSpan{start: start, end: len(line)})
+	}
+	return spans
+}
+
+// isNotSecretTokenRune returns whether r cannot appear in a base64- or
+// hex-like token, so it is suitable as the separator function for
+// strings.FieldsFunc.
+func isNotSecretTokenRune(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	case r == '+' || r == '/' || r == '=' || r == '-' || r == '_':
+		return false
+	default:
+		return true
+	}
+}
+
+// shannonEntropy returns the Shannon entropy of s, in bits per character.
+func shannonEntropy(s string) float64 {
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	length := float64(len(s))
+	var entropy float64
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
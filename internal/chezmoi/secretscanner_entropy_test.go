@@ -0,0 +1,53 @@
+package chezmoi
+
+import "testing"
+
+func TestShannonEntropy(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		s    string
+		want float64
+	}{
+		{name: "empty", s: "", want: 0},
+		{name: "single_repeated_char", s: "aaaaaaaaaa", want: 0},
+		{name: "two_distinct_chars_evenly_split", s: "abab", want: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := shannonEntropy(tc.s); got != tc.want {
+				t.Errorf("shannonEntropy(%q) == %v, want %v", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestEntropySecretScannerMinLengthThreshold verifies that the scanner's
+// configured threshold is achievable by a token of exactly minLength
+// characters, since Shannon entropy is bounded above by log2(minLength) bits
+// per character.
+func TestEntropySecretScannerMinLengthThreshold(t *testing.T) {
+	const minLength = 20
+	scanner := NewEntropySecretScanner(minLength, 4.0)
+	// A 20-character token using 20 distinct bytes has the maximum possible
+	// entropy for its length, log2(20) ~= 4.32 bits/char.
+	token := "abcdefghijklmnopqrst"
+	if len(token) != minLength {
+		t.Fatalf("len(%q) == %d, want %d", token, len(token), minLength)
+	}
+	findings := scanner.Scan("file", []byte(token))
+	if len(findings) != 1 {
+		t.Fatalf("Scan(%q) returned %d findings, want 1", token, len(findings))
+	}
+}
+
+func TestEntropySecretScannerDistinctOffsetsForRepeatedToken(t *testing.T) {
+	scanner := NewEntropySecretScanner(8, 2.5)
+	token := "aB3dE6fH" // 8 distinct-ish characters, appears twice on one line
+	content := token + " " + token
+	findings := scanner.Scan("file", []byte(content))
+	if len(findings) != 2 {
+		t.Fatalf("Scan(%q) returned %d findings, want 2", content, len(findings))
+	}
+	if findings[0].Offset == findings[1].Offset {
+		t.Errorf("both findings have offset %d, want distinct offsets", findings[0].Offset)
+	}
+}
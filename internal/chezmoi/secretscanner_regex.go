@@ -0,0 +1,81 @@
+package chezmoi
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A SecretRule is a single named regular expression rule, as used by
+// NewRegexSecretScanner and NewRegexSecretScannerFromYAML.
+type SecretRule struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+}
+
+type compiledSecretRule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// A regexSecretScanner is a SecretScanner that reports matches of a fixed set
+// of regular expression rules, for example a trufflehog-style signature pack
+// or a user-supplied rule file.
+type regexSecretScanner struct {
+	name  string
+	rules []compiledSecretRule
+}
+
+// NewRegexSecretScanner returns a SecretScanner called name that reports
+// matches of rules.
+func NewRegexSecretScanner(name string, rules []SecretRule) (SecretScanner, error) {
+	compiledRules := make([]compiledSecretRule, 0, len(rules))
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", name, rule.Name, err)
+		}
+		compiledRules = append(compiledRules, compiledSecretRule{
+			name:    rule.Name,
+			pattern: pattern,
+		})
+	}
+	return &regexSecretScanner{
+		name:  name,
+		rules: compiledRules,
+	}, nil
+}
+
+// NewRegexSecretScannerFromYAML returns a SecretScanner called name whose
+// rules are read from data, a YAML document containing a list of rules in
+// the same format accepted by NewRegexSecretScanner. This allows users to
+// supply their own rule packs without forking chezmoi.
+func NewRegexSecretScannerFromYAML(name string, data []byte) (SecretScanner, error) {
+	var rules []SecretRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return NewRegexSecretScanner(name, rules)
+}
+
+func (s *regexSecretScanner) Name() string {
+	return s.name
+}
+
+func (s *regexSecretScanner) Scan(path string, content []byte) []SecretFinding {
+	var findings []SecretFinding
+	for _, rule := range s.rules {
+		for _, match := range rule.pattern.FindAllIndex(content, -1) {
+			findings = append(findings, SecretFinding{
+				Scanner:     s.name,
+				Rule:        rule.name,
+				Offset:      match[0],
+				Line:        bytes.Count(content[:match[0]], []byte{'\n'}) + 1,
+				Description: fmt.Sprintf("matched rule %q", rule.name),
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,39 @@
+package chezmoi
+
+import "testing"
+
+func TestRegexSecretScannerScan(t *testing.T) {
+	scanner, err := NewRegexSecretScanner("test", []SecretRule{
+		{Name: "aws-key", Pattern: `AKIA[0-9A-Z]{16}`},
+	})
+	if err != nil {
+		t.Fatalf("NewRegexSecretScanner() == _, %v, want _, <nil>", err)
+	}
+
+	content := "line one\nkey=AKIA1234567890ABCDEF\n"
+	findings := scanner.Scan("file", []byte(content))
+	if len(findings) != 1 {
+		t.Fatalf("Scan() returned %d findings, want 1", len(findings))
+	}
+	if findings[0].Line != 2 {
+		t.Errorf("findings[0].Line == %d, want 2", findings[0].Line)
+	}
+	if findings[0].Rule != "aws-key" {
+		t.Errorf("findings[0].Rule == %q, want %q", findings[0].Rule, "aws-key")
+	}
+}
+
+func TestNewRegexSecretScannerFromYAML(t *testing.T) {
+	data := []byte(`
+- name: aws-key
+  pattern: 'AKIA[0-9A-Z]{16}'
+`)
+	scanner, err := NewRegexSecretScannerFromYAML("test", data)
+	if err != nil {
+		t.Fatalf("NewRegexSecretScannerFromYAML() == _, %v, want _, <nil>", err)
+	}
+	findings := scanner.Scan("file", []byte("AKIA1234567890ABCDEF"))
+	if len(findings) != 1 {
+		t.Fatalf("Scan() returned %d findings, want 1", len(findings))
+	}
+}
@@ -0,0 +1,54 @@
+package chezmoi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupeSecretFindings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		findings []SecretFinding
+		want     []SecretFinding
+	}{
+		{
+			name: "exact_duplicate_removed",
+			findings: []SecretFinding{
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 1, Offset: 4},
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 1, Offset: 4},
+			},
+			want: []SecretFinding{
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 1, Offset: 4},
+			},
+		},
+		{
+			name: "same_rule_and_offset_different_line_kept",
+			findings: []SecretFinding{
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 1, Offset: 4},
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 2, Offset: 4},
+			},
+			want: []SecretFinding{
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 1, Offset: 4},
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 2, Offset: 4},
+			},
+		},
+		{
+			name: "different_scanner_kept",
+			findings: []SecretFinding{
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 1, Offset: 4},
+				{Scanner: "entropy", Rule: "high-entropy-string", Line: 1, Offset: 4},
+			},
+			want: []SecretFinding{
+				{Scanner: "gitleaks", Rule: "generic-api-key", Line: 1, Offset: 4},
+				{Scanner: "entropy", Rule: "high-entropy-string", Line: 1, Offset: 4},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DedupeSecretFindings(tc.findings)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("DedupeSecretFindings(%v) == %v, want %v", tc.findings, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package chezmoimaps
+
+import (
+	"cmp"
+	"reflect"
+	"testing"
+)
+
+func TestSortedEntriesFunc(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2}
+	got := SortedEntriesFunc(m, func(a, b Entry[string, int]) int {
+		return cmp.Compare(a.K, b.K)
+	})
+	want := []Entry[string, int]{
+		{K: "a", V: 1},
+		{K: "b", V: 2},
+		{K: "c", V: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedEntriesFunc(%v) == %v, want %v", m, got, want)
+	}
+}
+
+func TestSortedKeysFunc(t *testing.T) {
+	m := map[int]string{3: "c", 1: "a", 2: "b"}
+	got := SortedKeysFunc(m, func(a, b int) int {
+		return cmp.Compare(b, a)
+	})
+	want := []int{3, 2, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeysFunc(%v) == %v, want %v", m, got, want)
+	}
+}
+
+func TestValues(t *testing.T) {
+	m := map[string]int{"a": 1}
+	got := Values(m)
+	want := []int{1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Values(%v) == %v, want %v", m, got, want)
+	}
+}
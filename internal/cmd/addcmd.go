@@ -3,16 +3,44 @@ package cmd
 import (
 	"fmt"
 	"io/fs"
+	"reflect"
 
 	"github.com/spf13/cobra"
 
 	"github.com/twpayne/chezmoi/v2/internal/chezmoi"
 )
 
+// An addSecretsCmdConfig contains the configuration for scanning files for
+// secrets when adding them.
+type addSecretsCmdConfig struct {
+	Severity severity `json:"severity" mapstructure:"severity" yaml:"severity"`
+	Scanners []string `json:"scanners" mapstructure:"scanners" yaml:"scanners"`
+}
+
+// addSecretsCmdConfigDecodeHook is a mapstructure decode hook that allows
+// add.secrets to still be configured as a bare scalar severity, as in
+// "add: {secrets: error}", for backwards compatibility with configurations
+// predating the addition of Scanners. Config files are decoded into a
+// generic map and then into Config with mapstructure, which does not invoke
+// yaml.Unmarshaler, so the legacy form must be handled here rather than with
+// a yaml.Unmarshaler method. It must be included alongside chezmoi's other
+// decode hooks in the mapstructure.DecoderConfig used to decode the config
+// file.
+func addSecretsCmdConfigDecodeHook(from, to reflect.Type, data any) (any, error) {
+	if to != reflect.TypeOf(addSecretsCmdConfig{}) || from.Kind() != reflect.String {
+		return data, nil
+	}
+	var secretsCmdConfig addSecretsCmdConfig
+	if err := secretsCmdConfig.Severity.Set(data.(string)); err != nil {
+		return nil, err
+	}
+	return secretsCmdConfig, nil
+}
+
 type addCmdConfig struct {
-	Encrypt          bool     `json:"encrypt"          mapstructure:"encrypt"          yaml:"encrypt"`
-	Secrets          severity `json:"secrets"          mapstructure:"secrets"          yaml:"secrets"`
-	TemplateSymlinks bool     `json:"templateSymlinks" mapstructure:"templateSymlinks" yaml:"templateSymlinks"`
+	Encrypt          bool                `json:"encrypt"          mapstructure:"encrypt"          yaml:"encrypt"`
+	Secrets          addSecretsCmdConfig `json:"secrets"          mapstructure:"secrets"          yaml:"secrets"`
+	TemplateSymlinks bool                `json:"templateSymlinks" mapstructure:"templateSymlinks" yaml:"templateSymlinks"`
 	autoTemplate     bool
 	create           bool
 	exact            bool
@@ -21,7 +49,11 @@ type addCmdConfig struct {
 	prompt           bool
 	quiet            bool
 	recursive        bool
+	review           bool
 	template         bool
+	secretScanners   []chezmoi.SecretScanner
+	secretFindings   map[chezmoi.AbsPath][]chezmoi.SecretFinding
+	reviewedAbsPaths map[chezmoi.AbsPath]struct{}
 }
 
 func (c *Config) newAddCmd() *cobra.Command {
@@ -58,7 +90,14 @@ func (c *Config) newAddCmd() *cobra.Command {
 	flags.BoolVarP(&c.Add.prompt, "prompt", "p", c.Add.prompt, "Prompt before adding each entry")
 	flags.BoolVarP(&c.Add.quiet, "quiet", "q", c.Add.quiet, "Suppress warnings")
 	flags.BoolVarP(&c.Add.recursive, "recursive", "r", c.Add.recursive, "Recurse into subdirectories")
-	flags.Var(&c.Add.Secrets, "secrets", "Scan for secrets when adding unencrypted files")
+	flags.BoolVar(&c.Add.review, "review", c.Add.review, "Review candidates before adding them to the source state")
+	flags.Var(&c.Add.Secrets.Severity, "secrets", "Scan for secrets when adding unencrypted files")
+	flags.StringSliceVar(
+		&c.Add.Secrets.Scanners,
+		"secrets-scanners",
+		c.Add.Secrets.Scanners,
+		"Secret scanners to use when scanning for secrets",
+	)
 	flags.BoolVarP(&c.Add.template, "template", "T", c.Add.template, "Add files as templates")
 	flags.BoolVar(
 		&c.Add.TemplateSymlinks,
@@ -82,22 +121,23 @@ func (c *Config) defaultOnIgnoreFunc(targetRelPath chezmoi.RelPath) {
 }
 
 func (c *Config) defaultPreAddFunc(targetRelPath chezmoi.RelPath, fileInfo fs.FileInfo) error {
-	// Scan unencrypted files for secrets, if configured.
-	if c.Add.Secrets != severityIgnore && fileInfo.Mode().Type() == 0 && !c.Add.Encrypt {
-		absPath := c.DestDirAbsPath.Join(targetRelPath)
-		content, err := c.destSystem.ReadFile(absPath)
-		if err != nil {
-			return err
-		}
-		gitleaksDetector, err := c.getGitleaksDetector()
+	absPath := c.DestDirAbsPath.Join(targetRelPath)
+	_, alreadyReviewed := c.Add.reviewedAbsPaths[absPath]
+
+	// Scan unencrypted files for secrets, if configured. Findings for files
+	// already reviewed in --review mode were already reported to the user,
+	// and the user already chose to keep the file, so don't report them
+	// again here and don't let Secrets.Severity == severityError abort an
+	// add that the user just approved.
+	if !alreadyReviewed && c.Add.Secrets.Severity != severityIgnore && fileInfo.Mode().Type() == 0 && !c.Add.Encrypt {
+		findings, err := c.findSecrets(absPath)
 		if err != nil {
 			return err
 		}
-		findings := gitleaksDetector.DetectBytes(content)
 		for _, finding := range findings {
-			c.errorf("%s:%d: %s\n", absPath, finding.StartLine+1, finding.Description)
+			c.errorf("%s:%d: %s\n", absPath, finding.Line, finding)
 		}
-		if !c.force && c.Add.Secrets == severityError && len(findings) > 0 {
+		if !c.force && c.Add.Secrets.Severity == severityError && len(findings) > 0 {
 			return chezmoi.ExitCodeError(1)
 		}
 	}
@@ -191,6 +231,20 @@ func (c *Config) runAddCmd(cmd *cobra.Command, args []string, sourceState *chezm
 		return err
 	}
 
+	// In --review mode, present every candidate to the user as a single
+	// aggregated list, letting them drop individual files or toggle their
+	// private and executable attributes, before committing anything to the
+	// source state. Template autogeneration and the encrypt and template
+	// attributes are not yet editable per file in --review mode; they still
+	// apply uniformly, as set by --autotemplate, --encrypt, and --template.
+	if c.Add.review {
+		var err error
+		destAbsPathInfos, err = c.reviewDestAbsPathInfos(destAbsPathInfos)
+		if err != nil {
+			return err
+		}
+	}
+
 	return sourceState.Add(
 		c.sourceSystem,
 		c.persistentState,
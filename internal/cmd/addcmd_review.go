@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+
+	"github.com/twpayne/chezmoi/v2/internal/chezmoi"
+	"github.com/twpayne/chezmoi/v2/internal/chezmoimaps"
+)
+
+// choicesAddReview are the choices offered to the user for the candidate
+// under review in --review mode.
+var choicesAddReview = []string{"yes", "no", "back", "toggle-private", "toggle-executable", "all", "quit"}
+
+// An addReviewStatus is the user's decision, if any, for an
+// addReviewCandidate.
+type addReviewStatus int
+
+const (
+	addReviewPending addReviewStatus = iota
+	addReviewKeep
+	addReviewDrop
+)
+
+// An addReviewCandidate is a single file pending review, with its current
+// attributes and any secrets detected in its content.
+type addReviewCandidate struct {
+	absPath       chezmoi.AbsPath
+	targetRelPath chezmoi.RelPath
+	fileInfo      fs.FileInfo
+	findings      []chezmoi.SecretFinding
+	status        addReviewStatus
+}
+
+// reviewDestAbsPathInfos presents every candidate in destAbsPathInfos
+// together, as an aggregated list that the user can step through in any
+// order, toggling private and executable attributes or dropping individual
+// files, before anything is added to the source state. It returns the
+// destAbsPathInfos for the candidates the user chose to keep.
+func (c *Config) reviewDestAbsPathInfos(
+	destAbsPathInfos map[chezmoi.AbsPath]fs.FileInfo,
+) (map[chezmoi.AbsPath]fs.FileInfo, error) {
+	sortedEntries := chezmoimaps.SortedEntriesFunc(
+		destAbsPathInfos,
+		func(a, b chezmoimaps.Entry[chezmoi.AbsPath, fs.FileInfo]) int {
+			return cmp.Compare(a.K, b.K)
+		},
+	)
+
+	candidates := make([]*addReviewCandidate, 0, len(sortedEntries))
+	for _, entry := range sortedEntries {
+		absPath, fileInfo := entry.K, entry.V
+		targetRelPath, err := absPath.TrimDirPrefix(c.DestDirAbsPath)
+		if err != nil {
+			return nil, err
+		}
+		candidate := &addReviewCandidate{
+			absPath:       absPath,
+			targetRelPath: targetRelPath,
+			fileInfo:      fileInfo,
+		}
+		if c.Add.Secrets.Severity != severityIgnore && fileInfo.Mode().Type() == 0 && !c.Add.Encrypt {
+			findings, err := c.findSecrets(absPath)
+			if err != nil {
+				return nil, err
+			}
+			candidate.findings = findings
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	index := 0
+	for index < len(candidates) {
+		c.printReviewList(candidates, index)
+		candidate := candidates[index]
+		choice, err := c.promptReviewCandidate(candidate)
+		if err != nil {
+			return nil, err
+		}
+		switch choice {
+		case "yes":
+			candidate.status = addReviewKeep
+			index++
+		case "no":
+			candidate.status = addReviewDrop
+			index++
+		case "back":
+			if index > 0 {
+				index--
+			}
+		case "all":
+			for _, remaining := range candidates[index:] {
+				if remaining.status == addReviewPending {
+					remaining.status = addReviewKeep
+				}
+			}
+			index = len(candidates)
+		case "quit":
+			return nil, chezmoi.ExitCodeError(0)
+		case "toggle-private":
+			toggleCandidateMode(candidate, togglePrivateMode)
+		case "toggle-executable":
+			toggleCandidateMode(candidate, toggleExecutableMode)
+		default:
+			panic(choice + ": unexpected choice")
+		}
+	}
+
+	kept := make(map[chezmoi.AbsPath]fs.FileInfo, len(candidates))
+	if c.Add.reviewedAbsPaths == nil {
+		c.Add.reviewedAbsPaths = make(map[chezmoi.AbsPath]struct{}, len(candidates))
+	}
+	for _, candidate := range candidates {
+		if candidate.status != addReviewKeep {
+			continue
+		}
+		kept[candidate.absPath] = candidate.fileInfo
+		c.Add.reviewedAbsPaths[candidate.absPath] = struct{}{}
+	}
+	return kept, nil
+}
+
+// printReviewList prints every candidate, marking which have already been
+// kept or dropped and which one, current, is now under review.
+func (c *Config) printReviewList(candidates []*addReviewCandidate, current int) {
+	c.errorf("reviewing %d file(s):\n", len(candidates))
+	for i, candidate := range candidates {
+		mark := " "
+		switch candidate.status {
+		case addReviewKeep:
+			mark = "x"
+		case addReviewDrop:
+			mark = "-"
+		}
+		pointer := " "
+		if i == current {
+			pointer = ">"
+		}
+		c.errorf("%s[%s] %s\n", pointer, mark, candidate.targetRelPath)
+	}
+}
+
+// promptReviewCandidate prints candidate's attributes and any secret
+// findings, then prompts the user for a single decision.
+func (c *Config) promptReviewCandidate(candidate *addReviewCandidate) (string, error) {
+	c.errorf(
+		"  private: %t, executable: %t\n",
+		isPrivate(candidate.fileInfo), isExecutable(candidate.fileInfo),
+	)
+	for _, finding := range candidate.findings {
+		c.errorf("  %s\n", finding)
+	}
+	prompt := fmt.Sprintf("add %s", candidate.targetRelPath)
+	return c.promptChoice(prompt, choicesAddReview)
+}
+
+// A modeOverrideFileInfo is an fs.FileInfo that reports a different mode
+// than the fs.FileInfo it wraps. It lets --review mode propose a modified
+// private or executable attribute for a candidate without modifying the
+// real file in the destination directory: chezmoi add must never mutate the
+// user's destination files.
+type modeOverrideFileInfo struct {
+	fs.FileInfo
+	mode fs.FileMode
+}
+
+func (i modeOverrideFileInfo) Mode() fs.FileMode {
+	return i.mode
+}
+
+// toggleCandidateMode replaces candidate.fileInfo with one reporting its
+// mode as modified by toggle, without touching the underlying file.
+func toggleCandidateMode(candidate *addReviewCandidate, toggle func(fs.FileMode) fs.FileMode) {
+	candidate.fileInfo = modeOverrideFileInfo{
+		FileInfo: candidate.fileInfo,
+		mode:     toggle(candidate.fileInfo.Mode()),
+	}
+}
+
+// togglePrivateMode returns mode with group and other permissions removed,
+// or, if mode already denies group and other all access, with group and
+// other read permission restored to match owner read permission.
+func togglePrivateMode(mode fs.FileMode) fs.FileMode {
+	if mode&0o077 == 0 {
+		return mode | 0o044
+	}
+	return mode &^ 0o077
+}
+
+// toggleExecutableMode returns mode with the owner, group, and other execute
+// bits flipped together.
+func toggleExecutableMode(mode fs.FileMode) fs.FileMode {
+	if mode&0o111 != 0 {
+		return mode &^ 0o111
+	}
+	return mode | 0o111
+}
+
+// findSecrets scans the file at absPath with all enabled secret scanners and
+// returns the deduplicated findings. Results are cached by absPath, so that
+// --review mode, which scans each file to populate the review prompt, does
+// not scan the same file again when it is later added.
+func (c *Config) findSecrets(absPath chezmoi.AbsPath) ([]chezmoi.SecretFinding, error) {
+	if findings, ok := c.Add.secretFindings[absPath]; ok {
+		return findings, nil
+	}
+
+	content, err := c.destSystem.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+	scanners, err := c.secretScanners()
+	if err != nil {
+		return nil, err
+	}
+	var findings []chezmoi.SecretFinding
+	for _, scanner := range scanners {
+		findings = append(findings, scanner.Scan(absPath.String(), content)...)
+	}
+	findings = chezmoi.DedupeSecretFindings(findings)
+
+	if c.Add.secretFindings == nil {
+		c.Add.secretFindings = make(map[chezmoi.AbsPath][]chezmoi.SecretFinding)
+	}
+	c.Add.secretFindings[absPath] = findings
+
+	return findings, nil
+}
+
+// isExecutable returns whether fileInfo's owner execute bit is set.
+func isExecutable(fileInfo fs.FileInfo) bool {
+	return fileInfo.Mode()&0o100 != 0
+}
+
+// isPrivate returns whether fileInfo denies all access to group and other.
+func isPrivate(fileInfo fs.FileInfo) bool {
+	return fileInfo.Mode()&0o77 == 0
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"io/fs"
+	"testing"
+)
+
+type fakeFileInfo struct {
+	fs.FileInfo
+	mode fs.FileMode
+}
+
+func (i fakeFileInfo) Mode() fs.FileMode {
+	return i.mode
+}
+
+func TestTogglePrivateMode(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		mode fs.FileMode
+		want fs.FileMode
+	}{
+		{name: "world_readable_becomes_private", mode: 0o644, want: 0o600},
+		{name: "private_becomes_group_and_other_readable", mode: 0o600, want: 0o644},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := togglePrivateMode(tc.mode); got != tc.want {
+				t.Errorf("togglePrivateMode(%v) == %v, want %v", tc.mode, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToggleExecutableMode(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		mode fs.FileMode
+		want fs.FileMode
+	}{
+		{name: "non_executable_becomes_executable", mode: 0o644, want: 0o755},
+		{name: "executable_becomes_non_executable", mode: 0o755, want: 0o644},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := toggleExecutableMode(tc.mode); got != tc.want {
+				t.Errorf("toggleExecutableMode(%v) == %v, want %v", tc.mode, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestToggleCandidateModeDoesNotMutateUnderlyingFileInfo verifies that
+// toggleCandidateMode only affects the candidate's reported mode and never
+// calls through to the real filesystem: chezmoi add must never mutate the
+// user's destination files.
+func TestToggleCandidateModeDoesNotMutateUnderlyingFileInfo(t *testing.T) {
+	underlying := fakeFileInfo{mode: 0o644}
+	candidate := &addReviewCandidate{fileInfo: underlying}
+
+	toggleCandidateMode(candidate, togglePrivateMode)
+
+	if got, want := candidate.fileInfo.Mode(), fs.FileMode(0o600); got != want {
+		t.Errorf("candidate.fileInfo.Mode() == %v, want %v", got, want)
+	}
+	if underlying.Mode() != 0o644 {
+		t.Errorf("underlying.Mode() == %v, want unchanged %v", underlying.Mode(), fs.FileMode(0o644))
+	}
+}
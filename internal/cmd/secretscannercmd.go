@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zricethezav/gitleaks/v8/detect"
+
+	"github.com/twpayne/chezmoi/v2/internal/chezmoi"
+)
+
+// gitleaksSecretScannerName is the name used to enable the gitleaks secret
+// scanner, and is the default if add.secrets.scanners is not set.
+const gitleaksSecretScannerName = "gitleaks"
+
+// entropySecretScannerName is the name used to enable the entropy-only
+// heuristic secret scanner. entropySecretScannerMinLength and
+// entropySecretScannerThreshold are the parameters it is constructed with.
+// threshold must be achievable by a token of minLength characters: Shannon
+// entropy is bounded above by log2(minLength) bits per character, which for
+// 20 is ~4.32, so the threshold is kept below that bound.
+const (
+	entropySecretScannerName      = "entropy"
+	entropySecretScannerMinLength = 20
+	entropySecretScannerThreshold = 4.0
+)
+
+// secretScannerFilePrefix is the prefix used to enable a user-supplied YAML
+// rule file as a secret scanner, for example "file:~/.secret-rules.yaml".
+const secretScannerFilePrefix = "file:"
+
+// A gitleaksSecretScanner is a chezmoi.SecretScanner that wraps a gitleaks
+// detector.
+type gitleaksSecretScanner struct {
+	detector *detect.Detector
+}
+
+func newGitleaksSecretScanner(detector *detect.Detector) chezmoi.SecretScanner {
+	return &gitleaksSecretScanner{
+		detector: detector,
+	}
+}
+
+func (s *gitleaksSecretScanner) Name() string {
+	return gitleaksSecretScannerName
+}
+
+func (s *gitleaksSecretScanner) Scan(path string, content []byte) []chezmoi.SecretFinding {
+	gitleaksFindings := s.detector.DetectBytes(content)
+	findings := make([]chezmoi.SecretFinding, 0, len(gitleaksFindings))
+	for _, gitleaksFinding := range gitleaksFindings {
+		findings = append(findings, chezmoi.SecretFinding{
+			Scanner: s.Name(),
+			Rule:    gitleaksFinding.RuleID,
+			// gitleaks only reports a column relative to the start of the
+			// line, not a whole-file byte offset, so Line must also be part
+			// of chezmoi.DedupeSecretFindings's key to avoid treating
+			// distinct findings on different lines as duplicates.
+			Offset:      gitleaksFinding.StartColumn,
+			Line:        gitleaksFinding.StartLine + 1,
+			Description: gitleaksFinding.Description,
+		})
+	}
+	return findings
+}
+
+// secretScanners returns the chezmoi.SecretScanners enabled by
+// c.Add.Secrets.Scanners, defaulting to gitleaks alone if none are
+// configured. The scanners are built once and cached, so that scanners
+// backed by expensive setup (for example, compiling a user-supplied rule
+// file) are not rebuilt for every file scanned.
+func (c *Config) secretScanners() ([]chezmoi.SecretScanner, error) {
+	if c.Add.secretScanners != nil {
+		return c.Add.secretScanners, nil
+	}
+
+	names := c.Add.Secrets.Scanners
+	if len(names) == 0 {
+		names = []string{gitleaksSecretScannerName}
+	}
+	scanners := make([]chezmoi.SecretScanner, 0, len(names))
+	for _, name := range names {
+		scanner, err := c.newSecretScanner(name)
+		if err != nil {
+			return nil, err
+		}
+		scanners = append(scanners, scanner)
+	}
+	c.Add.secretScanners = scanners
+	return scanners, nil
+}
+
+// newSecretScanner returns the chezmoi.SecretScanner named name.
+func (c *Config) newSecretScanner(name string) (chezmoi.SecretScanner, error) {
+	switch {
+	case name == gitleaksSecretScannerName:
+		gitleaksDetector, err := c.getGitleaksDetector()
+		if err != nil {
+			return nil, err
+		}
+		return newGitleaksSecretScanner(gitleaksDetector), nil
+	case name == entropySecretScannerName:
+		return chezmoi.NewEntropySecretScanner(entropySecretScannerMinLength, entropySecretScannerThreshold), nil
+	case strings.HasPrefix(name, secretScannerFilePrefix):
+		ruleFilePath := strings.TrimPrefix(name, secretScannerFilePrefix)
+		data, err := os.ReadFile(ruleFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return chezmoi.NewRegexSecretScannerFromYAML(name, data)
+	default:
+		return nil, fmt.Errorf("%s: unknown secret scanner", name)
+	}
+}